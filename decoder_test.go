@@ -0,0 +1,62 @@
+package gopts
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+type logLevel struct {
+	value string
+}
+
+func (l *logLevel) UnmarshalEnv(value string) error {
+	switch value {
+	case "debug", "info", "warn", "error":
+		l.value = value
+		return nil
+	default:
+		return fmt.Errorf("unknown log level %q", value)
+	}
+}
+
+type decoderConfig struct {
+	Level logLevel
+}
+
+func TestDecoderDecodesSetValue(t *testing.T) {
+	os.Setenv("DC_LEVEL", "warn")
+	defer os.Unsetenv("DC_LEVEL")
+
+	var c decoderConfig
+	if err := Load("dc", &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Level.value != "warn" {
+		t.Fatalf("level = %q, want %q", c.Level.value, "warn")
+	}
+}
+
+func TestDecoderReturnsFieldErrorOnInvalidValue(t *testing.T) {
+	os.Setenv("DC_LEVEL", "verbose")
+	defer os.Unsetenv("DC_LEVEL")
+
+	var c decoderConfig
+	err := Load("dc", &c)
+	if err == nil {
+		t.Fatal("expected an error for an invalid log level")
+	}
+	if _, ok := err.(*LoadError); !ok {
+		t.Fatalf("expected *LoadError, got %T", err)
+	}
+}
+
+func TestDecoderSkipsUnsetOptionalField(t *testing.T) {
+	var c decoderConfig
+	if err := Load("dc", &c); err != nil {
+		t.Fatalf("expected no error for an unset, non-required Decoder field: %v", err)
+	}
+	if c.Level.value != "" {
+		t.Fatalf("expected zero value, got %q", c.Level.value)
+	}
+}
@@ -2,31 +2,137 @@
 package gopts
 
 import (
+	"errors"
 	"fmt"
-	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// decoderType is the reflect.Type of the Decoder interface, used to probe
+// fields for a custom UnmarshalEnv implementation.
+var decoderType = reflect.TypeOf((*Decoder)(nil)).Elem()
+
+// Decoder lets a type take over parsing its own environment value. gopts
+// checks every field's pointer type (and, failing that, the field type
+// itself) for this interface before falling back to its built-in kind
+// switch. This is how types gopts doesn't know about natively - net.IP,
+// url.URL, regexp.Regexp, a custom enum - can still be loaded.
+type Decoder interface {
+	UnmarshalEnv(value string) error
+}
+
+// errRequired is the underlying error of every FieldError reported for a
+// field tagged required:"true" that neither the environment nor a "default"
+// tag supplied a value for.
+var errRequired = errors.New("required field is not set")
+
 // LoadEnvs is an alias to `LoadEnvsWithPrefix("", baseObj)`
 func LoadEnvs(baseObj interface{}) interface{} {
 	return LoadEnvsWithPrefix("", baseObj)
 }
 
+// FieldError describes a single field that gopts failed to populate from the
+// environment, carrying enough context to build an actionable error message:
+// the struct field name, the environment variable it was read from, the
+// value that was found there, the type gopts tried to coerce it into, and
+// the underlying parsing error.
+type FieldError struct {
+	Field  string
+	EnvVar string
+	Type   string
+	Value  string
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s (env %s): cannot parse %q as %s: %s", e.Field, e.EnvVar, e.Value, e.Type, e.Err)
+}
+
+// LoadError is returned by Load when one or more fields could not be parsed,
+// were tagged required:"true" but left unset, or failed their "validate" tag.
+// It aggregates every FieldError encountered so callers can report all of
+// the misconfiguration at once, instead of failing on the first bad value.
+type LoadError struct {
+	Fields []*FieldError
+}
+
+// Error implements the error interface.
+func (e *LoadError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, fieldErr := range e.Fields {
+		msgs[i] = fieldErr.Error()
+	}
+	return fmt.Sprintf("gopts: %d field(s) failed to load: %s", len(e.Fields), strings.Join(msgs, "; "))
+}
+
+// Load works like LoadEnvsWithPrefix, but takes a pointer to the struct to be
+// filled in, and returns a *LoadError instead of silently ignoring fields it
+// couldn't parse. This matches the ergonomics of libraries such as
+// kelseyhightower/envconfig, and is the preferred entry point for services
+// that need to fail fast on misconfiguration.
+//
+// It is equivalent to calling LoadFrom with only an EnvProvider.
+func Load(prefix string, target interface{}) error {
+	return LoadFrom(prefix, target, EnvProvider{})
+}
+
+// LoadFrom works like Load, but reads values from the given Providers instead
+// of the OS environment directly. Providers are queried in the order given,
+// and for any key provided by more than one, the value from the last
+// Provider that has it wins. This lets callers layer configuration sources,
+// e.g. LoadFrom(prefix, &cfg, fileProvider, gopts.EnvProvider{}) applies
+// fileProvider's values first and lets the environment override them.
+func LoadFrom(prefix string, target interface{}, providers ...Provider) error {
+	ptr := reflect.ValueOf(target)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return fmt.Errorf("gopts: target must be a non-nil pointer to a struct")
+	}
+	obj := ptr.Elem()
+	if obj.Kind() != reflect.Struct {
+		return fmt.Errorf("gopts: target must be a pointer to a struct")
+	}
+
+	if fieldErrs := loadInto(prefix, obj, lookupFunc(providers)); len(fieldErrs) > 0 {
+		return &LoadError{Fields: fieldErrs}
+	}
+	return nil
+}
+
+// lookupFunc combines a list of Providers into a single lookup function,
+// where the value returned by the last Provider that has the key wins.
+func lookupFunc(providers []Provider) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		var value string
+		found := false
+		for _, provider := range providers {
+			if v, ok := provider.Lookup(key); ok {
+				value = v
+				found = true
+			}
+		}
+		return value, found
+	}
+}
+
 // LoadEnvsWithPrefix loads data from environment variables into a managed
 // provided by you. Useful for loading settings stored in the OS environment.
 //
 // The following types are currently supported:
-// 	- bool
-// 	- int
-//  - int8
-//  - int16
-//  - int32
-//  - int64
-// 	- []string
-// 	- string
+//   - bool
+//   - int, int8, int16, int32, int64
+//   - float32, float64
+//   - string
+//   - []string, []int, []int64, []float64 (comma-separated)
+//   - map[string]string ("key:value,key:value")
+//   - time.Duration (parsed with time.ParseDuration)
+//   - nested structs, recursed into with the field name appended to the prefix
+//   - any type implementing Decoder
 //
 // gopts will use fields of the provided object to match environment keys. For
 // instance, it expects that a field named APIKey is available as `API_KEY`
@@ -41,35 +147,52 @@ func LoadEnvs(baseObj interface{}) interface{} {
 //
 // For instance, take the following struct:
 //
-// 	type Settings struct {
-// 	    Username 		string
-// 	    SecretKey 		string	`default:"s3cr37"`
-// 	    AutoRestart 	bool	`default:"true"`
-// 	    IgnoredField 	string	`gopts:"-"`
-// 	}
+//	type Settings struct {
+//	    Username 		string
+//	    SecretKey 		string	`default:"s3cr37"`
+//	    AutoRestart 	bool	`default:"true"`
+//	    IgnoredField 	string	`gopts:"-"`
+//	}
 //
 // and the following environment variables:
-// 	- PREF_USERNAME=Rob
-// 	- PREF_AUTO_RESTART=false
+//   - PREF_USERNAME=Rob
+//   - PREF_AUTO_RESTART=false
 //
 // running the following snippet:
 //
-// 	settings := gopts.LoadEnvsWithPrefix("pref", Settings{}).(Settings)
+//	settings := gopts.LoadEnvsWithPrefix("pref", Settings{}).(Settings)
 //
 // will yield the following result:
-// 	{
-// 			Username: 		"Rob",
-// 			SecretKey: 		"s3cr37",
-// 			AutoRestart: 	false,
-// 			IgnoredField:	""
-// 	}
 //
+//	{
+//			Username: 		"Rob",
+//			SecretKey: 		"s3cr37",
+//			AutoRestart: 	false,
+//			IgnoredField:	""
+//	}
+//
+// Parse errors (a malformed int, an out-of-range float, ...) are silently
+// ignored, leaving the field at its zero value (a slice or map field keeps
+// the entries that did parse); use Load if you need to know about them.
 func LoadEnvsWithPrefix(prefix string, baseObj interface{}) interface{} {
 	baseObjType := reflect.TypeOf(baseObj)
 	objPtr := reflect.New(baseObjType)
 	obj := reflect.Indirect(objPtr)
-	for i := 0; i < baseObjType.NumField(); i++ {
-		field := baseObjType.Field(i)
+	// Errors are intentionally discarded here to preserve this function's
+	// long-standing behavior; use Load if you need to know about them.
+	loadInto(prefix, obj, EnvProvider{}.Lookup)
+	return objPtr.Elem().Convert(baseObjType).Interface()
+}
+
+// loadInto fills the fields of obj (an addressable struct value) using
+// lookup to resolve environment variable names to values, and returns a
+// FieldError for every field that could not be parsed into its target type,
+// was required but unset, or failed validation.
+func loadInto(prefix string, obj reflect.Value, lookup func(string) (string, bool)) []*FieldError {
+	var fieldErrs []*FieldError
+	objType := obj.Type()
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
 		var def *string
 		if prefData, ok := field.Tag.Lookup("gopts"); ok {
 			if prefData == "-" {
@@ -83,50 +206,199 @@ func LoadEnvsWithPrefix(prefix string, baseObj interface{}) interface{} {
 				def = &alias
 			}
 		}
-		envName := snakeCase(field.Name)
-		if prefix != "" {
-			envName = fmt.Sprintf("%s_%s", prefix, envName)
+		envName := buildEnvName(prefix, field.Name)
+		targetField := obj.Field(i)
+
+		if decoder, ok := decoderFor(targetField); ok {
+			envName = strings.ToUpper(envName)
+			envValue, _ := lookup(envName)
+			if envValue == "" && def != nil {
+				envValue = *def
+			}
+			if field.Tag.Get("required") == "true" && envValue == "" {
+				fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Err: errRequired})
+				continue
+			}
+			if envValue == "" {
+				continue
+			}
+			if err := decoder.UnmarshalEnv(envValue); err != nil {
+				fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Value: envValue, Err: err})
+				continue
+			}
+			if err := validateField(field, targetField); err != nil {
+				fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Value: envValue, Err: err})
+			}
+			continue
 		}
-		envValue := os.Getenv(strings.ToUpper(envName))
+
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			if !isRecursable(field.Type) {
+				fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: strings.ToUpper(envName), Type: field.Type.String(), Err: fmt.Errorf("struct has unexported fields, gopts cannot recurse into it; implement Decoder instead")})
+				continue
+			}
+			for _, childErr := range loadInto(envName, targetField, lookup) {
+				childErr.Field = field.Name + "." + childErr.Field
+				fieldErrs = append(fieldErrs, childErr)
+			}
+			continue
+		}
+
+		envName = strings.ToUpper(envName)
+		envValue, _ := lookup(envName)
 		if envValue == "" && def != nil {
 			envValue = *def
 		}
 
-		targetField := obj.Field(i)
-		switch field.Type.Kind() {
-		case reflect.Bool:
+		if field.Tag.Get("required") == "true" && envValue == "" {
+			fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Err: errRequired})
+			continue
+		}
+
+		fieldErrCount := len(fieldErrs)
+		switch {
+		case field.Type == durationType:
+			if envValue == "" {
+				break
+			}
+			if d, err := time.ParseDuration(envValue); err == nil {
+				targetField.SetInt(int64(d))
+			} else {
+				fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Value: envValue, Err: err})
+			}
+		case field.Type.Kind() == reflect.Bool:
 			targetField.SetBool(boolFromString(envValue))
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		case field.Type.Kind() == reflect.Int, field.Type.Kind() == reflect.Int8, field.Type.Kind() == reflect.Int16, field.Type.Kind() == reflect.Int32, field.Type.Kind() == reflect.Int64:
+			if envValue == "" {
+				break
+			}
 			if intValue, err := strconv.Atoi(envValue); err == nil {
 				targetField.SetInt(int64(intValue))
+			} else {
+				fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Value: envValue, Err: err})
 			}
-		case reflect.Slice:
-			if reflect.TypeOf(field.Type.Elem()).Kind() == reflect.Ptr {
-				// Assuming as String, this will have to change anytime soon
-				// we support other slice types
-				var values []string
-				if len(envValue) == 0 {
-					values = []string{}
-				} else {
-					values = strings.Split(envValue, ",")
-				}
-				targetField.Set(reflect.ValueOf(values))
+		case field.Type.Kind() == reflect.Float32, field.Type.Kind() == reflect.Float64:
+			if envValue == "" {
 				break
 			}
-			fallthrough
-		case reflect.Float32:
-			if val, err := strconv.ParseFloat(envValue, 32); err == nil {
-				targetField.SetFloat(val)
+			bitSize := 64
+			if field.Type.Kind() == reflect.Float32 {
+				bitSize = 32
 			}
-		case reflect.Float64:
-			if val, err := strconv.ParseFloat(envValue, 64); err == nil {
+			if val, err := strconv.ParseFloat(envValue, bitSize); err == nil {
 				targetField.SetFloat(val)
+			} else {
+				fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Value: envValue, Err: err})
 			}
-		default:
+		case field.Type.Kind() == reflect.Map:
+			if field.Type.Key().Kind() != reflect.String || field.Type.Elem().Kind() != reflect.String {
+				fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Value: envValue, Err: fmt.Errorf("unsupported map type, only map[string]string is supported")})
+				break
+			}
+			values := reflect.MakeMap(field.Type)
+			if envValue != "" {
+				for _, pair := range strings.Split(envValue, ",") {
+					key, value, found := strings.Cut(pair, ":")
+					if !found {
+						fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Value: envValue, Err: fmt.Errorf("malformed entry %q, expected key:value", pair)})
+						continue
+					}
+					values.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(value))
+				}
+			}
+			targetField.Set(values)
+		case field.Type.Kind() == reflect.Slice:
+			elemKind := field.Type.Elem().Kind()
+			var items []string
+			if envValue != "" {
+				items = strings.Split(envValue, ",")
+			}
+			switch elemKind {
+			case reflect.String:
+				targetField.Set(reflect.ValueOf(items))
+			case reflect.Int, reflect.Int64:
+				values := reflect.MakeSlice(field.Type, 0, len(items))
+				for _, item := range items {
+					intValue, err := strconv.ParseInt(item, 10, 64)
+					if err != nil {
+						fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Value: item, Err: err})
+						continue
+					}
+					elem := reflect.New(field.Type.Elem()).Elem()
+					elem.SetInt(intValue)
+					values = reflect.Append(values, elem)
+				}
+				targetField.Set(values)
+			case reflect.Float64:
+				values := reflect.MakeSlice(field.Type, 0, len(items))
+				for _, item := range items {
+					floatValue, err := strconv.ParseFloat(item, 64)
+					if err != nil {
+						fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Value: item, Err: err})
+						continue
+					}
+					values = reflect.Append(values, reflect.ValueOf(floatValue))
+				}
+				targetField.Set(values)
+			default:
+				fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Value: envValue, Err: fmt.Errorf("unsupported slice element type %s", elemKind)})
+			}
+		case field.Type.Kind() == reflect.String:
 			targetField.SetString(envValue)
+		default:
+			if envValue == "" {
+				break
+			}
+			fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Value: envValue, Err: fmt.Errorf("unsupported field type %s", field.Type)})
+		}
+
+		if len(fieldErrs) == fieldErrCount {
+			if err := validateField(field, targetField); err != nil {
+				fieldErrs = append(fieldErrs, &FieldError{Field: field.Name, EnvVar: envName, Type: field.Type.String(), Value: envValue, Err: err})
+			}
 		}
 	}
-	return objPtr.Elem().Convert(baseObjType).Interface()
+	return fieldErrs
+}
+
+// decoderFor returns the Decoder implementation for targetField, checking
+// its pointer type first (the common case for a pointer-receiver
+// UnmarshalEnv) and falling back to the field's own type.
+func decoderFor(targetField reflect.Value) (Decoder, bool) {
+	if targetField.CanAddr() {
+		if addr := targetField.Addr(); addr.Type().Implements(decoderType) {
+			return addr.Interface().(Decoder), true
+		}
+	}
+	if targetField.Type().Implements(decoderType) {
+		return targetField.Interface().(Decoder), true
+	}
+	return nil, false
+}
+
+// isRecursable reports whether t is a struct gopts can safely recurse into:
+// every one of its fields must be exported. Types like time.Time or the
+// *url.Userinfo field inside url.URL hold unexported state that reflect
+// refuses to set, so descending into them panics; such types should be
+// handled through a Decoder instead.
+func isRecursable(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// buildEnvName joins a prefix and a struct field name into the environment
+// variable name gopts looks up, following the same PREFIX_FIELD_NAME
+// convention used at the top level.
+func buildEnvName(prefix, fieldName string) string {
+	envName := snakeCase(fieldName)
+	if prefix != "" {
+		envName = fmt.Sprintf("%s_%s", prefix, envName)
+	}
+	return envName
 }
 
 func snakeCase(in string) string {
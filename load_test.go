@@ -0,0 +1,94 @@
+package gopts
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+type loadConfig struct {
+	Username string `required:"true"`
+	Port     int    `default:"8080"`
+	Ignored  string `gopts:"-"`
+}
+
+func TestLoadPopulatesFieldsFromEnv(t *testing.T) {
+	os.Setenv("LC_USERNAME", "rob")
+	defer os.Unsetenv("LC_USERNAME")
+
+	var c loadConfig
+	if err := Load("lc", &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Username != "rob" {
+		t.Fatalf("Username = %q, want %q", c.Username, "rob")
+	}
+	if c.Port != 8080 {
+		t.Fatalf("Port = %d, want 8080 (from default tag)", c.Port)
+	}
+}
+
+func TestLoadIgnoresFieldsTaggedDash(t *testing.T) {
+	os.Setenv("LC_USERNAME", "rob")
+	os.Setenv("LC_IGNORED", "should not be read")
+	defer os.Unsetenv("LC_USERNAME")
+	defer os.Unsetenv("LC_IGNORED")
+
+	var c loadConfig
+	if err := Load("lc", &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Ignored != "" {
+		t.Fatalf("Ignored = %q, want empty, field is tagged gopts:\"-\"", c.Ignored)
+	}
+}
+
+func TestLoadReturnsLoadErrorOnMissingRequiredField(t *testing.T) {
+	var c loadConfig
+	err := Load("lc", &c)
+	if err == nil {
+		t.Fatal("expected an error, Username is required and unset")
+	}
+	loadErr, ok := err.(*LoadError)
+	if !ok {
+		t.Fatalf("expected *LoadError, got %T", err)
+	}
+	if len(loadErr.Fields) != 1 {
+		t.Fatalf("expected 1 field error, got %d", len(loadErr.Fields))
+	}
+	fieldErr := loadErr.Fields[0]
+	if fieldErr.Field != "Username" || fieldErr.EnvVar != "LC_USERNAME" {
+		t.Fatalf("fieldErr = %+v, want Field=Username EnvVar=LC_USERNAME", fieldErr)
+	}
+}
+
+func TestFieldErrorMessageIncludesContext(t *testing.T) {
+	fieldErr := &FieldError{Field: "Port", EnvVar: "LC_PORT", Type: "int", Value: "nope", Err: errRequired}
+	msg := fieldErr.Error()
+	for _, want := range []string{"Port", "LC_PORT", "nope", "int"} {
+		if !strings.Contains(msg, want) {
+			t.Fatalf("Error() = %q, expected it to contain %q", msg, want)
+		}
+	}
+}
+
+func TestLoadErrorMessageAggregatesFieldErrors(t *testing.T) {
+	loadErr := &LoadError{Fields: []*FieldError{
+		{Field: "Username", EnvVar: "LC_USERNAME", Type: "string", Err: errRequired},
+		{Field: "Port", EnvVar: "LC_PORT", Type: "int", Value: "nope", Err: errRequired},
+	}}
+	msg := loadErr.Error()
+	if !strings.Contains(msg, "2 field(s)") {
+		t.Fatalf("Error() = %q, expected it to report 2 field(s)", msg)
+	}
+}
+
+func TestLoadEnvsWithPrefixSilentlyIgnoresParseErrors(t *testing.T) {
+	os.Setenv("LC_PORT", "not-a-number")
+	defer os.Unsetenv("LC_PORT")
+
+	result := LoadEnvsWithPrefix("lc", loadConfig{}).(loadConfig)
+	if result.Port != 0 {
+		t.Fatalf("Port = %d, want 0 (zero value, parse error silently ignored)", result.Port)
+	}
+}
@@ -0,0 +1,47 @@
+package gopts
+
+import (
+	"os"
+	"strings"
+)
+
+// Provider is a source of configuration values, keyed by the same
+// upper-cased, underscore-separated names gopts derives from struct field
+// names (e.g. "APP_SERVER_PORT"). LoadFrom queries one or more Providers in
+// order, letting callers layer configuration sources such as a defaults
+// file, a config file, and the OS environment.
+type Provider interface {
+	// Lookup returns the value for key and whether it was present.
+	Lookup(key string) (string, bool)
+}
+
+// EnvProvider is a Provider backed by the OS environment. It is the
+// Provider Load and LoadEnvsWithPrefix use internally.
+type EnvProvider struct{}
+
+// Lookup implements Provider.
+func (EnvProvider) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// mapProvider is a Provider backed by an in-memory map, keyed
+// case-insensitively. It backs the file-based providers (dotenv, JSON,
+// YAML), which all reduce to a flat set of key/value pairs.
+type mapProvider map[string]string
+
+// newMapProvider builds a mapProvider from raw, upper-casing every key so
+// that Lookup matches regardless of how the key was cased in the source
+// file.
+func newMapProvider(raw map[string]string) mapProvider {
+	m := make(mapProvider, len(raw))
+	for k, v := range raw {
+		m[strings.ToUpper(k)] = v
+	}
+	return m
+}
+
+// Lookup implements Provider.
+func (m mapProvider) Lookup(key string) (string, bool) {
+	v, ok := m[strings.ToUpper(key)]
+	return v, ok
+}
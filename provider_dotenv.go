@@ -0,0 +1,52 @@
+package gopts
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// NewDotenvProvider reads a .env-style file and returns a Provider backed by
+// its contents. Lines are of the form KEY=VALUE, blank lines and lines
+// starting with # are ignored, and values may optionally be wrapped in
+// single or double quotes. No variable expansion is performed.
+func NewDotenvProvider(path string) (Provider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("gopts: opening dotenv file: %w", err)
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("gopts: %s:%d: malformed line, expected KEY=VALUE", path, lineNo)
+		}
+		values[strings.TrimSpace(key)] = unquote(strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("gopts: reading dotenv file: %w", err)
+	}
+
+	return newMapProvider(values), nil
+}
+
+// unquote strips a single layer of matching single or double quotes from s,
+// leaving it untouched if it isn't quoted.
+func unquote(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+	first, last := s[0], s[len(s)-1]
+	if (first == '"' && last == '"') || (first == '\'' && last == '\'') {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,44 @@
+package gopts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// NewJSONProvider reads a flat JSON object file and returns a Provider
+// backed by its contents. The file must decode to a JSON object whose
+// values are strings, numbers, or booleans; nested objects and arrays are
+// not supported, matching the flat key/value shape of an environment.
+func NewJSONProvider(path string) (Provider, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gopts: reading JSON file: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("gopts: parsing JSON file: %w", err)
+	}
+
+	values := make(map[string]string, len(fields))
+	for key, value := range fields {
+		switch v := value.(type) {
+		case string:
+			values[key] = v
+		case json.Number:
+			values[key] = v.String()
+		case nil:
+			values[key] = ""
+		case map[string]interface{}, []interface{}:
+			return nil, fmt.Errorf("gopts: %s: key %q is not a flat value", path, key)
+		default:
+			values[key] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	return newMapProvider(values), nil
+}
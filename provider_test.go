@@ -0,0 +1,135 @@
+package gopts
+
+import (
+	"os"
+	"testing"
+)
+
+func writeTemp(t *testing.T, pattern, contents string) string {
+	t.Helper()
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(contents); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Remove(f.Name()) })
+	return f.Name()
+}
+
+func TestEnvProviderLooksUpOSEnvironment(t *testing.T) {
+	os.Setenv("EP_LOOKUP_TEST", "value")
+	defer os.Unsetenv("EP_LOOKUP_TEST")
+
+	value, ok := EnvProvider{}.Lookup("EP_LOOKUP_TEST")
+	if !ok || value != "value" {
+		t.Fatalf("Lookup = %q, %v; want \"value\", true", value, ok)
+	}
+
+	if _, ok := (EnvProvider{}).Lookup("EP_LOOKUP_TEST_UNSET"); ok {
+		t.Fatal("expected Lookup to report false for an unset variable")
+	}
+}
+
+type loadFromConfig struct {
+	Username string
+	Port     int
+}
+
+func TestLoadFromLetsLaterProvidersOverrideEarlierOnes(t *testing.T) {
+	base := newMapProvider(map[string]string{"LF_USERNAME": "default", "LF_PORT": "80"})
+	override := newMapProvider(map[string]string{"LF_USERNAME": "override"})
+
+	var c loadFromConfig
+	if err := LoadFrom("lf", &c, base, override); err != nil {
+		t.Fatal(err)
+	}
+	if c.Username != "override" {
+		t.Fatalf("Username = %q, want %q (override should win)", c.Username, "override")
+	}
+	if c.Port != 80 {
+		t.Fatalf("Port = %d, want 80 (from base, untouched by override)", c.Port)
+	}
+}
+
+func TestDotenvProviderParsesBasicFile(t *testing.T) {
+	path := writeTemp(t, "*.env", "# a comment\nUSERNAME=rob\nPORT=8080\n")
+	provider, err := NewDotenvProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := provider.Lookup("USERNAME"); !ok || value != "rob" {
+		t.Fatalf("USERNAME = %q, %v; want \"rob\", true", value, ok)
+	}
+	if value, ok := provider.Lookup("PORT"); !ok || value != "8080" {
+		t.Fatalf("PORT = %q, %v; want \"8080\", true", value, ok)
+	}
+}
+
+func TestYAMLProviderParsesBasicFile(t *testing.T) {
+	path := writeTemp(t, "*.yaml", "username: rob\nport: 8080\n")
+	provider, err := NewYAMLProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := provider.Lookup("username"); !ok || value != "rob" {
+		t.Fatalf("username = %q, %v; want \"rob\", true", value, ok)
+	}
+	if value, ok := provider.Lookup("port"); !ok || value != "8080" {
+		t.Fatalf("port = %q, %v; want \"8080\", true", value, ok)
+	}
+}
+
+func TestJSONProviderParsesBasicFile(t *testing.T) {
+	path := writeTemp(t, "*.json", `{"username": "rob", "port": 8080}`)
+	provider, err := NewJSONProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := provider.Lookup("username"); !ok || value != "rob" {
+		t.Fatalf("username = %q, %v; want \"rob\", true", value, ok)
+	}
+	if value, ok := provider.Lookup("port"); !ok || value != "8080" {
+		t.Fatalf("port = %q, %v; want \"8080\", true", value, ok)
+	}
+}
+
+func TestDotenvProviderIgnoresInlineHash(t *testing.T) {
+	path := writeTemp(t, "*.env", `PASSWORD=s3cr#t`+"\n")
+	provider, err := NewDotenvProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, ok := provider.Lookup("PASSWORD")
+	if !ok || value != "s3cr#t" {
+		t.Fatalf("PASSWORD = %q, %v; want \"s3cr#t\", true", value, ok)
+	}
+}
+
+func TestYAMLProviderDoesNotTruncateValuesContainingHash(t *testing.T) {
+	path := writeTemp(t, "*.yaml", `password: "s3cr#t"`+"\n# a real comment\n")
+	provider, err := NewYAMLProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, ok := provider.Lookup("password")
+	if !ok || value != "s3cr#t" {
+		t.Fatalf("password = %q, %v; want \"s3cr#t\", true", value, ok)
+	}
+}
+
+func TestJSONProviderPreservesLargeIntegerPrecision(t *testing.T) {
+	path := writeTemp(t, "*.json", `{"ID": 9007199254740993}`)
+	provider, err := NewJSONProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, ok := provider.Lookup("ID")
+	if !ok || value != "9007199254740993" {
+		t.Fatalf("ID = %q, %v; want \"9007199254740993\", true", value, ok)
+	}
+}
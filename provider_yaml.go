@@ -0,0 +1,45 @@
+package gopts
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// NewYAMLProvider reads a flat YAML file and returns a Provider backed by
+// its contents. Only the subset of YAML needed to express a flat key/value
+// map is supported: one `key: value` pair per line, lines starting with #
+// as comments, and blank lines. As with NewDotenvProvider, only a
+// #-prefixed line is treated as a comment; a # appearing inside a value
+// (e.g. a password or URL fragment) is kept as-is. Nested maps, lists, and
+// multi-document files are not supported.
+func NewYAMLProvider(path string) (Provider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("gopts: opening YAML file: %w", err)
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(scanner.Text(), " ") || strings.HasPrefix(scanner.Text(), "\t") {
+			return nil, fmt.Errorf("gopts: %s:%d: indentation is not supported in flat YAML files", path, lineNo)
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			return nil, fmt.Errorf("gopts: %s:%d: malformed line, expected \"key: value\"", path, lineNo)
+		}
+		values[strings.TrimSpace(key)] = unquote(strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("gopts: reading YAML file: %w", err)
+	}
+
+	return newMapProvider(values), nil
+}
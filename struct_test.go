@@ -0,0 +1,95 @@
+package gopts
+
+import (
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+type structConfigAddress struct {
+	City string
+	Zip  string
+}
+
+type structConfig struct {
+	Address  structConfigAddress
+	Tags     map[string]string
+	Timeout  time.Duration
+	Backends []string
+}
+
+func TestLoadRecursesIntoNestedStructs(t *testing.T) {
+	os.Setenv("SC_ADDRESS_CITY", "Lisbon")
+	os.Setenv("SC_ADDRESS_ZIP", "1000-001")
+	defer os.Unsetenv("SC_ADDRESS_CITY")
+	defer os.Unsetenv("SC_ADDRESS_ZIP")
+
+	var c structConfig
+	if err := Load("sc", &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Address.City != "Lisbon" || c.Address.Zip != "1000-001" {
+		t.Fatalf("address = %+v, want {Lisbon 1000-001}", c.Address)
+	}
+}
+
+func TestLoadParsesMapSliceAndDuration(t *testing.T) {
+	os.Setenv("SC_TAGS", "env:prod,region:eu")
+	os.Setenv("SC_TIMEOUT", "5s")
+	os.Setenv("SC_BACKENDS", "a,b,c")
+	defer os.Unsetenv("SC_TAGS")
+	defer os.Unsetenv("SC_TIMEOUT")
+	defer os.Unsetenv("SC_BACKENDS")
+
+	var c structConfig
+	if err := Load("sc", &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Tags["env"] != "prod" || c.Tags["region"] != "eu" {
+		t.Fatalf("tags = %+v, want env:prod,region:eu", c.Tags)
+	}
+	if c.Timeout != 5*time.Second {
+		t.Fatalf("timeout = %v, want 5s", c.Timeout)
+	}
+	if len(c.Backends) != 3 || c.Backends[0] != "a" || c.Backends[2] != "c" {
+		t.Fatalf("backends = %+v, want [a b c]", c.Backends)
+	}
+}
+
+type timeConfig struct {
+	CreatedAt time.Time
+}
+
+func TestLoadDoesNotPanicOnTimeTime(t *testing.T) {
+	var c timeConfig
+	err := Load("tc", &c)
+	if err == nil {
+		t.Fatal("expected an error, time.Time has unexported fields and cannot be recursed into")
+	}
+	if _, ok := err.(*LoadError); !ok {
+		t.Fatalf("expected *LoadError, got %T", err)
+	}
+}
+
+type urlConfig struct {
+	Endpoint url.URL
+}
+
+func TestLoadDoesNotPanicOnURLURL(t *testing.T) {
+	os.Setenv("UC_ENDPOINT_SCHEME", "https")
+	os.Setenv("UC_ENDPOINT_HOST", "example.com")
+	defer os.Unsetenv("UC_ENDPOINT_SCHEME")
+	defer os.Unsetenv("UC_ENDPOINT_HOST")
+
+	var c urlConfig
+	if err := Load("uc", &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Endpoint.Scheme != "https" || c.Endpoint.Host != "example.com" {
+		t.Fatalf("endpoint = %+v, want scheme https, host example.com", c.Endpoint)
+	}
+	if c.Endpoint.User != nil {
+		t.Fatalf("User = %+v, want nil (Ptr kind is safely skipped, not panicked on)", c.Endpoint.User)
+	}
+}
@@ -0,0 +1,56 @@
+package gopts
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// Usage writes a tab-aligned table describing every field gopts would load
+// from target to w: its environment variable name, type, default value,
+// whether it is required, and its description (from a "desc" tag). Values
+// of fields tagged secret:"true" are redacted, so Usage is safe to wire up
+// as a `myapp --print-config` / `--help` flag without leaking credentials.
+//
+// target may be a struct or a pointer to one.
+func Usage(target interface{}, w io.Writer) error {
+	val := reflect.ValueOf(target)
+	for val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("gopts: target must be a struct or pointer to a struct")
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ENV VAR\tTYPE\tDEFAULT\tREQUIRED\tDESCRIPTION")
+	writeUsageRows(tw, "", val.Type())
+	return tw.Flush()
+}
+
+// writeUsageRows writes one row per leaf field of t, recursing into nested
+// structs the same way loadInto does.
+func writeUsageRows(w io.Writer, prefix string, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if tag, ok := field.Tag.Lookup("gopts"); ok && tag == "-" {
+			continue
+		}
+
+		envName := buildEnvName(prefix, field.Name)
+		implementsDecoder := reflect.PtrTo(field.Type).Implements(decoderType) || field.Type.Implements(decoderType)
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType && !implementsDecoder && isRecursable(field.Type) {
+			writeUsageRows(w, envName, field.Type)
+			continue
+		}
+
+		def := field.Tag.Get("default")
+		if def != "" && field.Tag.Get("secret") == "true" {
+			def = "REDACTED"
+		}
+		required := field.Tag.Get("required") == "true"
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", strings.ToUpper(envName), field.Type.String(), def, required, field.Tag.Get("desc"))
+	}
+}
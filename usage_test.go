@@ -0,0 +1,47 @@
+package gopts
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type usageDecodedEndpoint struct {
+	url.URL
+}
+
+func (e *usageDecodedEndpoint) UnmarshalEnv(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	e.URL = *parsed
+	return nil
+}
+
+type usageTarget struct {
+	Endpoint usageDecodedEndpoint `desc:"the upstream endpoint" required:"true"`
+}
+
+func TestUsageTreatsDecoderFieldsAsLeaves(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Usage(&usageTarget{}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a header and a single field row, got %d lines:\n%s", len(lines), out)
+	}
+	if !strings.Contains(out, "ENDPOINT") {
+		t.Fatalf("expected a single ENDPOINT row, got:\n%s", out)
+	}
+	if !strings.Contains(out, "the upstream endpoint") {
+		t.Fatalf("expected the field's desc tag to survive, got:\n%s", out)
+	}
+	if strings.Contains(out, "ENDPOINT_SCHEME") || strings.Contains(out, "ENDPOINT_OPAQUE") {
+		t.Fatalf("Decoder field was exploded into its struct fields:\n%s", out)
+	}
+}
@@ -0,0 +1,139 @@
+package gopts
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// validateField runs the rules in field's "validate" tag against the value
+// already assigned to targetField, returning the first rule that fails.
+//
+// The tag holds a comma-separated list of rules:
+//
+//	validate:"min=1,max=10"
+//	validate:"oneof=debug|info|warn|error"
+//	validate:"regexp=^[a-zA-Z0-9_]+$"
+func validateField(field reflect.StructField, targetField reflect.Value) error {
+	tag, ok := field.Tag.Lookup("validate")
+	if !ok || tag == "" {
+		return nil
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		var err error
+		switch name {
+		case "min":
+			err = validateMin(targetField, arg)
+		case "max":
+			err = validateMax(targetField, arg)
+		case "oneof":
+			err = validateOneOf(targetField, arg)
+		case "regexp":
+			err = validateRegexp(targetField, arg)
+		default:
+			err = fmt.Errorf("unknown validation rule %q", name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// numericValue returns v's underlying numeric value, for fields validate
+// compares min/max against directly.
+func numericValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// lengthValue returns the length of v, for fields where min/max describe a
+// string, slice, or map length rather than a numeric value.
+func lengthValue(v reflect.Value) (int, bool) {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return v.Len(), true
+	default:
+		return 0, false
+	}
+}
+
+func validateMin(v reflect.Value, arg string) error {
+	if num, ok := numericValue(v); ok {
+		threshold, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("invalid min=%q: %w", arg, err)
+		}
+		if num < threshold {
+			return fmt.Errorf("must be >= %s", arg)
+		}
+		return nil
+	}
+	if length, ok := lengthValue(v); ok {
+		threshold, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid min=%q: %w", arg, err)
+		}
+		if length < threshold {
+			return fmt.Errorf("length must be >= %s", arg)
+		}
+		return nil
+	}
+	return fmt.Errorf("min is not supported for type %s", v.Type())
+}
+
+func validateMax(v reflect.Value, arg string) error {
+	if num, ok := numericValue(v); ok {
+		threshold, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("invalid max=%q: %w", arg, err)
+		}
+		if num > threshold {
+			return fmt.Errorf("must be <= %s", arg)
+		}
+		return nil
+	}
+	if length, ok := lengthValue(v); ok {
+		threshold, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid max=%q: %w", arg, err)
+		}
+		if length > threshold {
+			return fmt.Errorf("length must be <= %s", arg)
+		}
+		return nil
+	}
+	return fmt.Errorf("max is not supported for type %s", v.Type())
+}
+
+func validateOneOf(v reflect.Value, arg string) error {
+	value := fmt.Sprintf("%v", v.Interface())
+	for _, option := range strings.Split(arg, "|") {
+		if option == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", arg)
+}
+
+func validateRegexp(v reflect.Value, pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid regexp=%q: %w", pattern, err)
+	}
+	value := fmt.Sprintf("%v", v.Interface())
+	if !re.MatchString(value) {
+		return fmt.Errorf("must match %s", pattern)
+	}
+	return nil
+}